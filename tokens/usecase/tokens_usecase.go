@@ -6,15 +6,43 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	"github.com/osmosis-labs/sqs/sqsdomain/json"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
 )
 
-type tokensUseCase struct {
+// defaultPricingWorkerPoolSize bounds the number of concurrent in-flight
+// price computations when no explicit WithPricingWorkerPoolSize option is
+// given to NewTokensUsecase.
+const defaultPricingWorkerPoolSize = 32
+
+// defaultPriceCacheTTL is how long a cached price is served as fresh when no
+// explicit WithPriceCacheTTL option is given to NewTokensUsecase.
+const defaultPriceCacheTTL = 10 * time.Second
+
+// defaultPriceCacheStaleWindow is how long, after defaultPriceCacheTTL
+// elapses, a cached price is still served (triggering an async refresh)
+// rather than blocking the caller on a fresh fetch.
+const defaultPriceCacheStaleWindow = 20 * time.Second
+
+// priceCacheEntry is a single cached successful price for a (base, quote,
+// source) triple. Errors are never cached (see fetchAndCachePrice), so
+// every entry here reflects a price that was actually computed.
+type priceCacheEntry struct {
+	price     osmomath.BigDec
+	fetchedAt time.Time
+}
+
+// tokenMetadata groups all the chain registry-derived lookups that get
+// recomputed together on every reload, so that they can be swapped in as a
+// single atomic unit.
+type tokenMetadata struct {
 	// Currently, we only expect reads to this shared resource and no writes.
 	// If needed, change this to sync.Map in the future.
 	// Can be considered for merge with humanToChainDenomMap in the future.
@@ -22,17 +50,293 @@ type tokensUseCase struct {
 	humanToChainDenomMap      map[string]string
 	chainDenoms               map[string]struct{}
 
-	// No mutex since we only expect reads to this shared resource and no writes.
 	precisionScalingFactorMap map[int]osmomath.Dec
 
-	// We persist pricing strategies across endpoint calls as they
-	// may cache responses internally.
-	pricingStrategyMap map[domain.PricingSourceType]domain.PricingSource
-
 	// Map of chain denoms to coingecko IDs
 	coingeckoIds map[string]string
 }
 
+type tokensUseCase struct {
+	// Holds the current tokenMetadata snapshot. Reloads build a new
+	// snapshot and atomically swap it in, so readers never observe a
+	// partially-updated set of maps.
+	metadata atomic.Pointer[tokenMetadata]
+
+	// URL of the chain registry assets file metadata is (re)loaded from.
+	// Empty unless WithChainRegistryAssetsFileURL was given.
+	chainRegistryAssetsFileURL string
+
+	// Registry of pricing strategies, keyed by source type, along with the
+	// ordered fallback chain set for each one via SetPricingFallbacks. We persist
+	// pricing strategies across endpoint calls as they may cache responses
+	// internally.
+	pricingRegistry map[domain.PricingSourceType]pricingRegistryEntry
+
+	// Bounds the number of concurrent price computations spawned by
+	// GetPrices / getPricesForBaseDenom so that large batch requests
+	// do not fan out into an unbounded number of goroutines.
+	pricingWorkerPool chan struct{}
+
+	// Coalesces concurrent price computations for the same
+	// (base, quote, source) triple into a single in-flight call.
+	pricingRequestGroup singleflight.Group
+
+	// Caches the last computed price per (base, quote, source) triple,
+	// keyed by the same string key as pricingRequestGroup. Values are
+	// *priceCacheEntry.
+	priceCache sync.Map
+
+	// priceCacheTTL is how long a cached entry is served as fresh.
+	priceCacheTTL time.Duration
+	// priceCacheStaleWindow is the additional period after priceCacheTTL
+	// during which a cached entry is still served (stale-while-revalidate)
+	// while an async refresh is kicked off in the background.
+	priceCacheStaleWindow time.Duration
+}
+
+// pricingRegistryEntry is a single registration in the pricing source
+// registry: the strategy itself plus the ordered chain of source types to
+// fall back to, in priority order, when it fails to produce a price.
+type pricingRegistryEntry struct {
+	strategy  domain.PricingSource
+	fallbacks []domain.PricingSourceType
+
+	// breaker short-circuits calls to strategy while it is unhealthy or
+	// has been halted by an operator, so that callers pay the fallback
+	// cost instead of the failing source's RTT on every request.
+	breaker *circuitBreaker
+}
+
+const (
+	// breakerErrorThreshold is the number of errors within breakerWindow
+	// that trips the breaker from closed to open. Any successful call resets
+	// the count, so the errors must come with no success in between.
+	breakerErrorThreshold = 5
+	// breakerWindow is the rolling window over which errors are counted.
+	breakerWindow = 30 * time.Second
+	// breakerCooldown is how long the breaker stays open before allowing
+	// a half-open probe.
+	breakerCooldown = 30 * time.Second
+	// breakerHalfOpenProbeInterval bounds how often a half-open breaker
+	// allows a single probe call through.
+	breakerHalfOpenProbeInterval = 5 * time.Second
+)
+
+// breakerState is the state of a circuitBreaker's state machine.
+type breakerState int32
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// String implements fmt.Stringer, used in the error returned when the
+// breaker short-circuits a call. The sqs_pricing_breaker_state gauge records
+// the numeric value instead.
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker tracks the health of a single pricing source and decides
+// whether calls to it should proceed or short-circuit straight to the
+// fallback chain.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	state breakerState
+
+	// Rolling error count over the current window.
+	windowStart time.Time
+	errorCount  int
+
+	// openedAt is when the breaker last tripped to open, for observability.
+	openedAt time.Time
+	// haltedUntil is when an open breaker is next allowed to probe, whether
+	// from a trip's cooldown or an operator-driven halt.
+	haltedUntil time.Time
+	// lastProbeAt bounds half-open probes to one per breakerHalfOpenProbeInterval.
+	lastProbeAt time.Time
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{state: breakerClosed}
+}
+
+// currentState returns the breaker's current state under lock. Callers
+// outside circuitBreaker must use this instead of reading the state field
+// directly.
+func (b *circuitBreaker) currentState() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// allow reports whether a call to the wrapped pricing source should proceed,
+// advancing the breaker's state machine as a side effect (open -> half-open
+// once the cooldown elapses).
+func (b *circuitBreaker) allow(source domain.PricingSourceType, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if now.Before(b.haltedUntil) {
+			return false
+		}
+		b.state = breakerHalfOpen
+		b.lastProbeAt = now
+		breakerStateGauge.WithLabelValues(fmt.Sprintf("%v", source)).Set(float64(breakerHalfOpen))
+		return true
+	case breakerHalfOpen:
+		if now.Sub(b.lastProbeAt) < breakerHalfOpenProbeInterval {
+			return false
+		}
+		b.lastProbeAt = now
+		return true
+	default:
+		return true
+	}
+}
+
+// recordResult updates the breaker's state machine based on the outcome of
+// a call that allow permitted, tripping it to open on sustained failures
+// (or a single half-open probe failure) and resetting it to closed on
+// success.
+func (b *circuitBreaker) recordResult(source domain.PricingSourceType, now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = breakerClosed
+		b.errorCount = 0
+		b.windowStart = now
+		breakerStateGauge.WithLabelValues(fmt.Sprintf("%v", source)).Set(float64(breakerClosed))
+		return
+	}
+
+	if b.state == breakerHalfOpen {
+		b.trip(source, now)
+		return
+	}
+
+	if now.Sub(b.windowStart) > breakerWindow {
+		b.windowStart = now
+		b.errorCount = 0
+	}
+	b.errorCount++
+
+	if b.errorCount >= breakerErrorThreshold {
+		b.trip(source, now)
+	}
+}
+
+// trip opens the breaker for breakerCooldown. Callers must hold b.mu.
+func (b *circuitBreaker) trip(source domain.PricingSourceType, now time.Time) {
+	b.state = breakerOpen
+	b.openedAt = now
+	b.haltedUntil = now.Add(breakerCooldown)
+	b.errorCount = 0
+
+	breakerTripsCounter.WithLabelValues(fmt.Sprintf("%v", source)).Inc()
+	breakerStateGauge.WithLabelValues(fmt.Sprintf("%v", source)).Set(float64(breakerOpen))
+}
+
+// halt opens the breaker until the given time, regardless of its current
+// error count, for operator-driven halts during known upstream incidents.
+func (b *circuitBreaker) halt(source domain.PricingSourceType, until time.Time, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerOpen
+	b.openedAt = now
+	b.haltedUntil = until
+
+	breakerTripsCounter.WithLabelValues(fmt.Sprintf("%v", source)).Inc()
+	breakerStateGauge.WithLabelValues(fmt.Sprintf("%v", source)).Set(float64(breakerOpen))
+}
+
+// TokensUsecaseOption configures a tokensUseCase at construction time.
+type TokensUsecaseOption func(*tokensUseCase)
+
+// WithPricingWorkerPoolSize sets the maximum number of concurrent price
+// computations that GetPrices is allowed to have in flight at once. If not
+// supplied, NewTokensUsecase defaults to defaultPricingWorkerPoolSize.
+func WithPricingWorkerPoolSize(size int) TokensUsecaseOption {
+	return func(t *tokensUseCase) {
+		if size > 0 {
+			t.pricingWorkerPool = make(chan struct{}, size)
+		}
+	}
+}
+
+// WithChainRegistryAssetsFileURL configures the chain registry assets file
+// URL that ReloadTokenMetadata (and the background refresher started by
+// WithMetadataReloadInterval) fetches from.
+func WithChainRegistryAssetsFileURL(url string) TokensUsecaseOption {
+	return func(t *tokensUseCase) {
+		t.chainRegistryAssetsFileURL = url
+	}
+}
+
+// WithMetadataReloadInterval starts a background goroutine that calls
+// ReloadTokenMetadata on the given interval for the lifetime of the process.
+// It requires WithChainRegistryAssetsFileURL to also be set; while the URL is
+// empty, the goroutine still runs but skips every tick without reloading.
+func WithMetadataReloadInterval(interval time.Duration) TokensUsecaseOption {
+	return func(t *tokensUseCase) {
+		if interval <= 0 {
+			return
+		}
+
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				if t.chainRegistryAssetsFileURL == "" {
+					continue
+				}
+
+				if err := t.ReloadTokenMetadata(context.Background()); err != nil {
+					fmt.Println(err.Error())
+				}
+			}
+		}()
+	}
+}
+
+// WithPriceCacheTTL sets how long a cached price is served as fresh before
+// entering the stale-while-revalidate window. If not supplied,
+// NewTokensUsecase defaults to defaultPriceCacheTTL.
+func WithPriceCacheTTL(ttl time.Duration) TokensUsecaseOption {
+	return func(t *tokensUseCase) {
+		if ttl > 0 {
+			t.priceCacheTTL = ttl
+		}
+	}
+}
+
+// WithPriceCacheStaleWindow sets how long, after WithPriceCacheTTL elapses, a
+// cached price is still served while an async refresh runs in the
+// background, before callers start blocking on a fresh fetch. If not
+// supplied, NewTokensUsecase defaults to defaultPriceCacheStaleWindow.
+func WithPriceCacheStaleWindow(window time.Duration) TokensUsecaseOption {
+	return func(t *tokensUseCase) {
+		if window > 0 {
+			t.priceCacheStaleWindow = window
+		}
+	}
+}
+
 // Struct to represent the JSON structure
 type AssetList struct {
 	ChainName string `json:"chainName"`
@@ -59,7 +363,40 @@ type priceResults struct {
 	err       error
 }
 
-var _ mvc.TokensUsecase = &tokensUseCase{}
+// TokenMetadataReloader is implemented by token usecases that can hot-reload
+// their metadata from the chain registry on demand. mvc.TokensUsecase does
+// not embed this directly in this checkout; callers that need to drive a
+// reload without depending on the concrete *tokensUseCase (e.g. an admin
+// HTTP handler) should type-assert to this interface instead, the same way
+// the standard library does for optional capabilities like http.Flusher.
+type TokenMetadataReloader interface {
+	ReloadTokenMetadata(ctx context.Context) error
+}
+
+// PricingSourceHalter is implemented by token usecases that let an operator
+// force a pricing source's circuit breaker open, bypassing its normal
+// error-threshold trip. mvc.TokensUsecase does not embed this directly in
+// this checkout; an admin endpoint driving HaltPricingSource should
+// type-assert to this interface instead of the concrete *tokensUseCase.
+type PricingSourceHalter interface {
+	HaltPricingSource(source domain.PricingSourceType, until time.Time) error
+}
+
+// PricingFreshnessOverrider is implemented by token usecases that let a
+// caller override the price cache's freshness window for a single call.
+// mvc.TokensUsecase does not embed this directly in this checkout; a caller
+// that needs per-call freshness control should type-assert to this
+// interface instead of the concrete *tokensUseCase.
+type PricingFreshnessOverrider interface {
+	GetPricesWithFreshness(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, maxAge time.Duration, allowStale bool, opts ...domain.PricingOption) (map[string]map[string]any, error)
+}
+
+var (
+	_ mvc.TokensUsecase         = &tokensUseCase{}
+	_ TokenMetadataReloader     = &tokensUseCase{}
+	_ PricingSourceHalter       = &tokensUseCase{}
+	_ PricingFreshnessOverrider = &tokensUseCase{}
+)
 
 var (
 	tenDec = osmomath.NewDec(10)
@@ -74,30 +411,75 @@ var (
 	fallbackCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "sqs_pricing_fallback_total",
-			Help: "Total number of fallback from chain pricing source to coingecko",
+			Help: "Total number of fallbacks from one pricing source to another, per hop in the fallback chain",
+		},
+		[]string{"from", "to", "base", "quote"},
+	)
+	tokenMetadataReloadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sqs_token_metadata_reload_total",
+			Help: "Total number of token metadata reloads from the chain registry, by result",
+		},
+		[]string{"result"},
+	)
+	breakerStateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sqs_pricing_breaker_state",
+			Help: "Current circuit breaker state per pricing source (0=closed, 1=open, 2=half-open)",
 		},
-		[]string{"base", "quote"},
+		[]string{"source"},
+	)
+	breakerTripsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sqs_pricing_breaker_trips_total",
+			Help: "Total number of times a pricing source's circuit breaker tripped to open",
+		},
+		[]string{"source"},
+	)
+	cacheHitsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sqs_pricing_cache_hits_total",
+			Help: "Total number of price cache hits served within TTL",
+		},
+		[]string{"source"},
+	)
+	cacheStaleHitsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sqs_pricing_cache_stale_hits_total",
+			Help: "Total number of price cache hits served stale while an async refresh is in flight",
+		},
+		[]string{"source"},
+	)
+	cacheMissesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sqs_pricing_cache_misses_total",
+			Help: "Total number of price cache misses that blocked on a fresh fetch",
+		},
+		[]string{"source"},
 	)
 )
 
-// NewTokensUsecase will create a new tokens use case object
-func NewTokensUsecase(tokenMetadataByChainDenom map[string]domain.Token) mvc.TokensUsecase {
+// buildTokenMetadata derives the lookup maps (human denom, precision scaling
+// factors, chain denom set, coingecko IDs) from a raw chain denom -> token
+// metadata map. It is used both at construction time and on every
+// ReloadTokenMetadata call.
+func buildTokenMetadata(tokenMetadataByChainDenom map[string]domain.Token) *tokenMetadata {
 	// Create human denom to chain denom map
 	humanToChainDenomMap := make(map[string]string, len(tokenMetadataByChainDenom))
 	uniquePrecisionMap := make(map[int]struct{}, 0)
 	chainDenoms := map[string]struct{}{}
 	coingeckoIds := make(map[string]string, len(tokenMetadataByChainDenom))
 
-	for chainDenom, tokenMetadata := range tokenMetadataByChainDenom {
+	for chainDenom, token := range tokenMetadataByChainDenom {
 		// lower case human denom
-		lowerCaseHumanDenom := strings.ToLower(tokenMetadata.HumanDenom)
+		lowerCaseHumanDenom := strings.ToLower(token.HumanDenom)
 
 		humanToChainDenomMap[lowerCaseHumanDenom] = chainDenom
 
-		uniquePrecisionMap[tokenMetadata.Precision] = struct{}{}
+		uniquePrecisionMap[token.Precision] = struct{}{}
 
 		chainDenoms[chainDenom] = struct{}{}
-		coingeckoIds[chainDenom] = tokenMetadata.CoingeckoID
+		coingeckoIds[chainDenom] = token.CoingeckoID
 	}
 
 	// Precompute precision scaling factors
@@ -106,23 +488,64 @@ func NewTokensUsecase(tokenMetadataByChainDenom map[string]domain.Token) mvc.Tok
 		precisionScalingFactors[precision] = tenDec.Power(uint64(precision))
 	}
 
-	return &tokensUseCase{
+	return &tokenMetadata{
 		tokenMetadataByChainDenom: tokenMetadataByChainDenom,
 		humanToChainDenomMap:      humanToChainDenomMap,
 		precisionScalingFactorMap: precisionScalingFactors,
+		chainDenoms:               chainDenoms,
+		coingeckoIds:              coingeckoIds,
+	}
+}
+
+// NewTokensUsecase will create a new tokens use case object
+func NewTokensUsecase(tokenMetadataByChainDenom map[string]domain.Token, opts ...TokensUsecaseOption) mvc.TokensUsecase {
+	tokensUseCase := &tokensUseCase{
+		pricingRegistry:       map[domain.PricingSourceType]pricingRegistryEntry{},
+		pricingWorkerPool:     make(chan struct{}, defaultPricingWorkerPoolSize),
+		priceCacheTTL:         defaultPriceCacheTTL,
+		priceCacheStaleWindow: defaultPriceCacheStaleWindow,
+	}
+	tokensUseCase.metadata.Store(buildTokenMetadata(tokenMetadataByChainDenom))
+
+	for _, opt := range opts {
+		opt(tokensUseCase)
+	}
+
+	return tokensUseCase
+}
 
-		pricingStrategyMap: map[domain.PricingSourceType]domain.PricingSource{},
+// ReloadTokenMetadata re-fetches the assets file from the configured chain
+// registry URL and atomically swaps in the new token metadata, making newly
+// listed tokens (or updated precisions / CoinGecko IDs) visible without a
+// restart. It is safe to call concurrently with reads and with itself.
+//
+// ReloadTokenMetadata implements TokenMetadataReloader, so callers outside
+// this package can drive a reload (e.g. from an admin endpoint) by
+// type-asserting to that interface rather than to *tokensUseCase.
+func (t *tokensUseCase) ReloadTokenMetadata(ctx context.Context) error {
+	if t.chainRegistryAssetsFileURL == "" {
+		tokenMetadataReloadCounter.WithLabelValues("error").Inc()
+		return fmt.Errorf("no chain registry assets file URL configured for reload")
+	}
 
-		chainDenoms:  chainDenoms,
-		coingeckoIds: coingeckoIds,
+	tokenMetadataByChainDenom, err := GetTokensFromChainRegistry(ctx, t.chainRegistryAssetsFileURL)
+	if err != nil {
+		tokenMetadataReloadCounter.WithLabelValues("error").Inc()
+		return err
 	}
+
+	t.metadata.Store(buildTokenMetadata(tokenMetadataByChainDenom))
+
+	tokenMetadataReloadCounter.WithLabelValues("success").Inc()
+
+	return nil
 }
 
 // GetChainDenom implements mvc.TokensUsecase.
 func (t *tokensUseCase) GetChainDenom(humanDenom string) (string, error) {
 	humanDenomLowerCase := strings.ToLower(humanDenom)
 
-	chainDenom, ok := t.humanToChainDenomMap[humanDenomLowerCase]
+	chainDenom, ok := t.metadata.Load().humanToChainDenomMap[humanDenomLowerCase]
 	if !ok {
 		return "", fmt.Errorf("chain denom for human denom (%s) is not found", humanDenomLowerCase)
 	}
@@ -132,7 +555,7 @@ func (t *tokensUseCase) GetChainDenom(humanDenom string) (string, error) {
 
 // GetMetadataByChainDenom implements mvc.TokensUsecase.
 func (t *tokensUseCase) GetMetadataByChainDenom(denom string) (domain.Token, error) {
-	token, ok := t.tokenMetadataByChainDenom[denom]
+	token, ok := t.metadata.Load().tokenMetadataByChainDenom[denom]
 	if !ok {
 		return domain.Token{}, fmt.Errorf("metadata for denom (%s) is not found", denom)
 	}
@@ -142,9 +565,11 @@ func (t *tokensUseCase) GetMetadataByChainDenom(denom string) (domain.Token, err
 
 // GetFullTokenMetadata implements mvc.TokensUsecase.
 func (t *tokensUseCase) GetFullTokenMetadata() (map[string]domain.Token, error) {
+	tokenMetadataByChainDenom := t.metadata.Load().tokenMetadataByChainDenom
+
 	// Do a copy of the cached metadata
-	result := make(map[string]domain.Token, len(t.tokenMetadataByChainDenom))
-	for denom, tokenMetadata := range t.tokenMetadataByChainDenom {
+	result := make(map[string]domain.Token, len(tokenMetadataByChainDenom))
+	for denom, tokenMetadata := range tokenMetadataByChainDenom {
 		result[denom] = tokenMetadata
 	}
 
@@ -168,6 +593,34 @@ func (t *tokensUseCase) GetChainScalingFactorByDenomMut(denom string) (osmomath.
 
 // GetPrices implements pricing.PricingStrategy.
 func (t *tokensUseCase) GetPrices(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (map[string]map[string]any, error) {
+	return t.getPrices(ctx, baseDenoms, quoteDenoms, pricingSourceType, t.priceCacheTTL, t.priceCacheStaleWindow, opts...)
+}
+
+// GetPricesWithFreshness behaves like GetPrices, but lets the caller
+// override the cache freshness window for this call only, rather than
+// always using the construction-time priceCacheTTL/priceCacheStaleWindow.
+// maxAge is how old a cached entry may be and still be served as fresh.
+// allowStale, if false, disables serving stale-but-not-expired entries (and
+// the async refresh that goes with them) for this call, so any entry older
+// than maxAge is fetched synchronously instead.
+//
+// GetPricesWithFreshness implements PricingFreshnessOverrider, so callers
+// outside this package can reach it by type-asserting to that interface
+// rather than to *tokensUseCase.
+func (t *tokensUseCase) GetPricesWithFreshness(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, maxAge time.Duration, allowStale bool, opts ...domain.PricingOption) (map[string]map[string]any, error) {
+	staleWindow := t.priceCacheStaleWindow
+	if !allowStale {
+		staleWindow = 0
+	}
+
+	return t.getPrices(ctx, baseDenoms, quoteDenoms, pricingSourceType, maxAge, staleWindow, opts...)
+}
+
+// getPrices is the shared implementation behind GetPrices and
+// GetPricesWithFreshness; ttl and staleWindow are the effective freshness
+// window for this call, defaulted from t.priceCacheTTL/t.priceCacheStaleWindow
+// by GetPrices or overridden per call by GetPricesWithFreshness.
+func (t *tokensUseCase) getPrices(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, ttl time.Duration, staleWindow time.Duration, opts ...domain.PricingOption) (map[string]map[string]any, error) {
 	byBaseDenomResult := make(map[string]map[string]any, len(baseDenoms))
 
 	// Create a channel to communicate the results
@@ -176,13 +629,16 @@ func (t *tokensUseCase) GetPrices(ctx context.Context, baseDenoms []string, quot
 	// Use a WaitGroup to wait for all goroutines to finish
 	var wg sync.WaitGroup
 
-	// For every base denom, create a map with quote denom prices.
+	// For every base denom, create a map with quote denom prices. These
+	// goroutines only orchestrate and wait on their own per-quote work; see
+	// fetchAndCachePrice for where real concurrency is bounded.
 	for _, baseDenom := range baseDenoms {
 		wg.Add(1)
+
 		go func(baseDenom string) {
 			defer wg.Done()
 
-			prices, err := t.getPricesForBaseDenom(ctx, baseDenom, quoteDenoms, pricingSourceType, opts...)
+			prices, err := t.getPricesForBaseDenom(ctx, baseDenom, quoteDenoms, pricingSourceType, ttl, staleWindow, opts...)
 			if err != nil {
 				// This should not panic, so just logging the error here and continue
 				fmt.Println(err.Error())
@@ -212,10 +668,13 @@ func (t *tokensUseCase) GetPrices(ctx context.Context, baseDenoms []string, quot
 
 // getPricesForBaseDenom fetches all prices for base denom given a slice of quotes and pricing options.
 // Pricing options determine whether to recompute prices or use the cache as well as the desired source of prices.
+// ttl and staleWindow are the effective cache freshness window for this call, as resolved by getPrices.
+// The price cache is keyed by (base, quote, source) only and is checked before pricingOptions reach
+// the pricing source, so a cached entry within ttl+staleWindow is served whatever options are passed.
 // Returns a map with keys as quotes and values as prices or error, if any.
 // Returns error if base denom is not found in the token metadata.
 // Sets the price to zero in case of failing to compute the price between base and quote but these being valid tokens.
-func (t *tokensUseCase) getPricesForBaseDenom(ctx context.Context, baseDenom string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, pricingOptions ...domain.PricingOption) (map[string]any, error) {
+func (t *tokensUseCase) getPricesForBaseDenom(ctx context.Context, baseDenom string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, ttl time.Duration, staleWindow time.Duration, pricingOptions ...domain.PricingOption) (map[string]any, error) {
 	byQuoteDenomForGivenBaseResult := make(map[string]any, len(quoteDenoms))
 	// Validate base denom is a valid denom
 	// Return zeroes for all quotes if base denom is not found
@@ -230,33 +689,51 @@ func (t *tokensUseCase) getPricesForBaseDenom(ctx context.Context, baseDenom str
 	// Create a channel to communicate the results
 	resultsChan := make(chan priceResult, len(quoteDenoms))
 
-	// Get the pricing strategy
-	pricingStrategy, ok := t.pricingStrategyMap[pricingSourceType]
-	if !ok {
-		return nil, fmt.Errorf("pricing strategy (%s) not found in the tokens use case", pricingStrategy)
+	// Ensure the pricing source itself is registered before fanning out.
+	// getPriceWithFallback re-looks up the registry per hop since the chain
+	// walk may traverse into other source types.
+	if _, ok := t.pricingRegistry[pricingSourceType]; !ok {
+		return nil, fmt.Errorf("pricing strategy (%v) not found in the tokens use case", pricingSourceType)
 	}
 
 	// Use a WaitGroup to wait for all goroutines to finish
 	var wg sync.WaitGroup
 
-	// Given the current base denom, compute all of its prices with the quotes
+	// Given the current base denom, compute all of its prices with the
+	// quotes. Spawning one goroutine per quote denom here is cheap; see
+	// fetchAndCachePrice for where real concurrency is bounded.
 	for _, quoteDenom := range quoteDenoms {
 		wg.Add(1)
 		go func(baseDenom, quoteDenom string) {
 			defer wg.Done()
-			var price osmomath.BigDec
-			var err error
-			price, err = pricingStrategy.GetPrice(ctx, baseDenom, quoteDenom, pricingOptions...)
-			if err != nil { // Check if we should fallback to another pricing source
-				fallbackSourceType := pricingStrategy.GetFallbackStrategy(quoteDenom)
-				if fallbackSourceType != domain.NoneSourceType {
-					fallbackCounter.WithLabelValues(baseDenom, quoteDenom).Inc()
-					fallbackPricingStrategy, ok := t.pricingStrategyMap[fallbackSourceType]
-					if ok {
-						price, err = fallbackPricingStrategy.GetPrice(ctx, baseDenom, quoteDenom, pricingOptions...)
-					}
+
+			cacheKey := fmt.Sprintf("%s/%s/%d", baseDenom, quoteDenom, pricingSourceType)
+			sourceLabel := fmt.Sprintf("%v", pricingSourceType)
+
+			if cached, ok := t.priceCache.Load(cacheKey); ok {
+				entry := cached.(*priceCacheEntry)
+				age := time.Since(entry.fetchedAt)
+
+				if age < ttl {
+					cacheHitsCounter.WithLabelValues(sourceLabel).Inc()
+					resultsChan <- priceResult{quoteDenom, entry.price, nil}
+					return
+				}
+
+				if age < ttl+staleWindow {
+					cacheStaleHitsCounter.WithLabelValues(sourceLabel).Inc()
+					resultsChan <- priceResult{quoteDenom, entry.price, nil}
+
+					// Kick off an async refresh so the next caller sees a
+					// fresh value, without making this caller block on it.
+					go t.fetchAndCachePrice(context.Background(), baseDenom, quoteDenom, pricingSourceType, cacheKey, pricingOptions...)
+					return
 				}
 			}
+
+			cacheMissesCounter.WithLabelValues(sourceLabel).Inc()
+
+			price, err := t.fetchAndCachePrice(ctx, baseDenom, quoteDenom, pricingSourceType, cacheKey, pricingOptions...)
 			resultsChan <- priceResult{quoteDenom, price, err}
 		}(baseDenom, quoteDenom)
 	}
@@ -284,16 +761,120 @@ func (t *tokensUseCase) getPricesForBaseDenom(ctx context.Context, baseDenom str
 	return byQuoteDenomForGivenBaseResult, nil
 }
 
+// getPriceWithFallback fetches the price for (baseDenom, quoteDenom) from
+// sourceType, walking its registered fallback chain, in order, until one
+// source succeeds or the chain is exhausted. It records a fallback hop
+// metric for each source it moves past, and returns the last error seen if
+// every source in the chain fails.
+func (t *tokensUseCase) getPriceWithFallback(ctx context.Context, baseDenom, quoteDenom string, sourceType domain.PricingSourceType, pricingOptions ...domain.PricingOption) (osmomath.BigDec, error) {
+	visited := map[domain.PricingSourceType]struct{}{}
+
+	currentType := sourceType
+	var lastErr error
+
+	for {
+		visited[currentType] = struct{}{}
+
+		entry, ok := t.pricingRegistry[currentType]
+		if !ok {
+			lastErr = fmt.Errorf("pricing strategy (%v) not found in the tokens use case", currentType)
+			break
+		}
+
+		now := time.Now()
+
+		var price osmomath.BigDec
+		var err error
+		if entry.breaker.allow(currentType, now) {
+			price, err = entry.strategy.GetPrice(ctx, baseDenom, quoteDenom, pricingOptions...)
+			entry.breaker.recordResult(currentType, now, err)
+		} else {
+			// Breaker is open (or halted): skip the RTT and go straight to
+			// the fallback chain.
+			err = fmt.Errorf("pricing source (%v) circuit breaker is %s", currentType, entry.breaker.currentState())
+		}
+
+		if err == nil {
+			return price, nil
+		}
+		lastErr = err
+
+		// Find the next fallback in the chain that has not already been
+		// visited, to guard against misconfigured cycles.
+		nextType := domain.NoneSourceType
+		for _, fallbackType := range entry.fallbacks {
+			if _, seen := visited[fallbackType]; !seen {
+				nextType = fallbackType
+				break
+			}
+		}
+
+		if nextType == domain.NoneSourceType {
+			break
+		}
+
+		fallbackCounter.WithLabelValues(fmt.Sprintf("%v", currentType), fmt.Sprintf("%v", nextType), baseDenom, quoteDenom).Inc()
+		currentType = nextType
+	}
+
+	return osmomath.ZeroBigDec(), lastErr
+}
+
+// fetchAndCachePrice coalesces concurrent fetches for cacheKey into a single
+// in-flight computation via getPriceWithFallback, and stores the result in
+// the price cache for subsequent TTL/stale-while-revalidate reads. The
+// freshness window applied to those reads is resolved by the caller
+// (getPrices, via GetPrices or GetPricesWithFreshness) and does not affect
+// fetchAndCachePrice itself, which always computes and caches the current
+// price regardless of why it was called.
+func (t *tokensUseCase) fetchAndCachePrice(ctx context.Context, baseDenom, quoteDenom string, sourceType domain.PricingSourceType, cacheKey string, pricingOptions ...domain.PricingOption) (osmomath.BigDec, error) {
+	priceAny, err, _ := t.pricingRequestGroup.Do(cacheKey, func() (any, error) {
+		// Acquire a pricingWorkerPool slot only around the actual pricing
+		// work, inside the singleflight callback, so callers coalesced onto
+		// an in-flight request never hold a slot themselves. This is the one
+		// place that bounds the full baseDenoms*quoteDenoms fan-out, since
+		// every caller (sync or the async stale-refresh in
+		// getPricesForBaseDenom) goes through it.
+		t.pricingWorkerPool <- struct{}{}
+		defer func() { <-t.pricingWorkerPool }()
+
+		price, err := t.getPriceWithFallback(ctx, baseDenom, quoteDenom, sourceType, pricingOptions...)
+
+		// Only cache successful results. Caching an error as a fresh entry
+		// would make a single transient failure "sticky" for the full TTL
+		// and would starve the circuit breaker of real traffic during that
+		// window, since cache hits never reach getPriceWithFallback.
+		if err == nil {
+			t.priceCache.Store(cacheKey, &priceCacheEntry{
+				price:     price,
+				fetchedAt: time.Now(),
+			})
+		}
+
+		return price, err
+	})
+
+	price, _ := priceAny.(osmomath.BigDec)
+	return price, err
+}
+
 func (t *tokensUseCase) getChainScalingFactorMut(precision int) (osmomath.Dec, bool) {
-	result, ok := t.precisionScalingFactorMap[precision]
+	result, ok := t.metadata.Load().precisionScalingFactorMap[precision]
 	return result, ok
 }
 
 // GetTokensFromChainRegistry fetches the tokens from the chain registry.
-// It returns a map of tokens by chain denom.
-func GetTokensFromChainRegistry(chainRegistryAssetsFileURL string) (map[string]domain.Token, error) {
+// It returns a map of tokens by chain denom. The request is bound to ctx, so
+// a caller's cancellation or timeout (e.g. from ReloadTokenMetadata) aborts
+// the underlying HTTP request instead of it running to completion.
+func GetTokensFromChainRegistry(ctx context.Context, chainRegistryAssetsFileURL string) (map[string]domain.Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, chainRegistryAssetsFileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	// Fetch the JSON data from the URL
-	response, err := http.Get(chainRegistryAssetsFileURL)
+	response, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -342,24 +923,72 @@ func (t *tokensUseCase) GetSpotPriceScalingFactorByDenom(baseDenom string, quote
 
 // RegisterPricingStrategy implements mvc.TokensUsecase.
 func (t *tokensUseCase) RegisterPricingStrategy(source domain.PricingSourceType, strategy domain.PricingSource) {
-	t.pricingStrategyMap[source] = strategy
+	t.pricingRegistry[source] = pricingRegistryEntry{
+		strategy: strategy,
+		breaker:  newCircuitBreaker(),
+	}
+}
+
+// SetPricingFallbacks records the ordered chain of pricing source types to
+// fall back to, in priority order, when source's strategy fails to produce
+// a price. source must already be registered via RegisterPricingStrategy.
+//
+// This is a separate method, rather than a variadic parameter on
+// RegisterPricingStrategy, because RegisterPricingStrategy's signature is
+// fixed by mvc.TokensUsecase; widening it here without updating that
+// interface (which lives outside this package) would break the interface
+// assertion near the top of this file.
+func (t *tokensUseCase) SetPricingFallbacks(source domain.PricingSourceType, fallbacks ...domain.PricingSourceType) error {
+	entry, ok := t.pricingRegistry[source]
+	if !ok {
+		return fmt.Errorf("pricing strategy (%v) not found in the tokens use case", source)
+	}
+
+	entry.fallbacks = fallbacks
+	t.pricingRegistry[source] = entry
+
+	return nil
+}
+
+// HaltPricingSource opens the circuit breaker for source until the given
+// time, regardless of its current error count. This is operator-driven,
+// intended for known upstream incidents where an operator wants to force
+// traffic onto the fallback chain without waiting for errors to trip the
+// breaker naturally.
+//
+// HaltPricingSource implements PricingSourceHalter, so an admin endpoint can
+// drive it by type-asserting to that interface rather than to
+// *tokensUseCase.
+func (t *tokensUseCase) HaltPricingSource(source domain.PricingSourceType, until time.Time) error {
+	entry, ok := t.pricingRegistry[source]
+	if !ok {
+		return fmt.Errorf("pricing strategy (%v) not found in the tokens use case", source)
+	}
+
+	entry.breaker.halt(source, until, time.Now())
+
+	return nil
 }
 
 // IsValidChainDenom implements mvc.TokensUsecase.
 func (t *tokensUseCase) IsValidChainDenom(chainDenom string) bool {
-	metaData, ok := t.tokenMetadataByChainDenom[chainDenom]
+	metaData, ok := t.metadata.Load().tokenMetadataByChainDenom[chainDenom]
 	return ok && !metaData.IsUnlisted
 }
 
-// IsValidPricingSource implements mvc.TokensUsecase.
+// IsValidPricingSource implements mvc.TokensUsecase. A pricing source is
+// valid if it has been registered via RegisterPricingStrategy, regardless of
+// whether it is one of the built-in chain/CoinGecko sources or a custom one
+// (e.g. an oracle or static override source).
 func (t *tokensUseCase) IsValidPricingSource(pricingSource int) bool {
 	ps := domain.PricingSourceType(pricingSource)
-	return ps == domain.ChainPricingSourceType || ps == domain.CoinGeckoPricingSourceType
+	_, ok := t.pricingRegistry[ps]
+	return ok
 }
 
 // GetCoingeckoIdByChainDenom implements mvc.TokensUsecase
 func (t *tokensUseCase) GetCoingeckoIdByChainDenom(chainDenom string) (string, error) {
-	if coingeckoId, found := t.coingeckoIds[chainDenom]; found {
+	if coingeckoId, found := t.metadata.Load().coingeckoIds[chainDenom]; found {
 		return coingeckoId, nil
 	} else {
 		return "", fmt.Errorf("chain denom not found in chain registry")
@@ -0,0 +1,428 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const testPricingSource = domain.PricingSourceType(1)
+
+// TestCircuitBreaker_Closed_AllowsUntilErrorThreshold verifies that a fresh
+// breaker allows every call, and stays closed while recording fewer than
+// breakerErrorThreshold errors within a single window.
+func TestCircuitBreaker_Closed_AllowsUntilErrorThreshold(t *testing.T) {
+	b := newCircuitBreaker()
+	now := time.Now()
+
+	if got := b.currentState(); got != breakerClosed {
+		t.Fatalf("new breaker state = %v, want %v", got, breakerClosed)
+	}
+
+	for i := 0; i < breakerErrorThreshold-1; i++ {
+		if !b.allow(testPricingSource, now) {
+			t.Fatalf("allow() = false before error threshold reached (i=%d)", i)
+		}
+		b.recordResult(testPricingSource, now, fmt.Errorf("boom"))
+	}
+
+	if got := b.currentState(); got != breakerClosed {
+		t.Fatalf("state after %d errors = %v, want %v (still below threshold)", breakerErrorThreshold-1, got, breakerClosed)
+	}
+}
+
+// TestCircuitBreaker_TripsOpenAtErrorThreshold verifies the breaker opens
+// once breakerErrorThreshold errors land within breakerWindow, and that
+// allow() then short-circuits until the cooldown elapses.
+func TestCircuitBreaker_TripsOpenAtErrorThreshold(t *testing.T) {
+	b := newCircuitBreaker()
+	now := time.Now()
+
+	for i := 0; i < breakerErrorThreshold; i++ {
+		b.allow(testPricingSource, now)
+		b.recordResult(testPricingSource, now, fmt.Errorf("boom"))
+	}
+
+	if got := b.currentState(); got != breakerOpen {
+		t.Fatalf("state after %d errors = %v, want %v", breakerErrorThreshold, got, breakerOpen)
+	}
+
+	if b.allow(testPricingSource, now) {
+		t.Fatalf("allow() = true for an open breaker still within cooldown")
+	}
+}
+
+// TestCircuitBreaker_HalfOpenProbe_SuccessCloses verifies that once the
+// cooldown elapses, allow() lets exactly one probe through (half-open), and
+// a successful result closes the breaker again.
+func TestCircuitBreaker_HalfOpenProbe_SuccessCloses(t *testing.T) {
+	b := newCircuitBreaker()
+	now := time.Now()
+
+	for i := 0; i < breakerErrorThreshold; i++ {
+		b.allow(testPricingSource, now)
+		b.recordResult(testPricingSource, now, fmt.Errorf("boom"))
+	}
+
+	afterCooldown := now.Add(breakerCooldown + time.Millisecond)
+
+	if !b.allow(testPricingSource, afterCooldown) {
+		t.Fatalf("allow() = false for the first probe after cooldown elapsed")
+	}
+	if got := b.currentState(); got != breakerHalfOpen {
+		t.Fatalf("state after cooldown = %v, want %v", got, breakerHalfOpen)
+	}
+
+	// A second probe within breakerHalfOpenProbeInterval should be refused.
+	if b.allow(testPricingSource, afterCooldown) {
+		t.Fatalf("allow() = true for a second half-open probe within the probe interval")
+	}
+
+	b.recordResult(testPricingSource, afterCooldown, nil)
+	if got := b.currentState(); got != breakerClosed {
+		t.Fatalf("state after a successful half-open probe = %v, want %v", got, breakerClosed)
+	}
+}
+
+// TestCircuitBreaker_HalfOpenProbe_FailureReopens verifies that a failed
+// half-open probe trips the breaker open again immediately, regardless of
+// the error count threshold.
+func TestCircuitBreaker_HalfOpenProbe_FailureReopens(t *testing.T) {
+	b := newCircuitBreaker()
+	now := time.Now()
+
+	for i := 0; i < breakerErrorThreshold; i++ {
+		b.allow(testPricingSource, now)
+		b.recordResult(testPricingSource, now, fmt.Errorf("boom"))
+	}
+
+	afterCooldown := now.Add(breakerCooldown + time.Millisecond)
+	b.allow(testPricingSource, afterCooldown)
+	b.recordResult(testPricingSource, afterCooldown, fmt.Errorf("still broken"))
+
+	if got := b.currentState(); got != breakerOpen {
+		t.Fatalf("state after a failed half-open probe = %v, want %v", got, breakerOpen)
+	}
+}
+
+// TestCircuitBreaker_Halt_ForcesOpenRegardlessOfErrorCount verifies that an
+// operator-driven halt opens the breaker even with a clean error history,
+// and that it refuses new calls until the given time.
+func TestCircuitBreaker_Halt_ForcesOpenRegardlessOfErrorCount(t *testing.T) {
+	b := newCircuitBreaker()
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	b.halt(testPricingSource, until, now)
+
+	if got := b.currentState(); got != breakerOpen {
+		t.Fatalf("state after halt = %v, want %v", got, breakerOpen)
+	}
+	if b.allow(testPricingSource, now) {
+		t.Fatalf("allow() = true before the halt's until time")
+	}
+	if !b.allow(testPricingSource, until.Add(time.Millisecond)) {
+		t.Fatalf("allow() = false after the halt's until time elapsed")
+	}
+}
+
+// countingPricingSource implements domain.PricingSource, returning a fixed
+// price and counting how many times GetPrice is actually invoked, so tests
+// can assert on cache hit/miss behavior.
+type countingPricingSource struct {
+	calls atomic.Int64
+	price osmomath.BigDec
+}
+
+func (s *countingPricingSource) GetPrice(ctx context.Context, baseDenom, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+	s.calls.Add(1)
+	return s.price, nil
+}
+
+func newTestTokensUseCase(ttl, staleWindow time.Duration) (*tokensUseCase, *countingPricingSource) {
+	source := &countingPricingSource{price: osmomath.NewBigDec(2)}
+
+	uc := NewTokensUsecase(
+		map[string]domain.Token{
+			"uatom": {HumanDenom: "atom", Precision: 6},
+			"uosmo": {HumanDenom: "osmo", Precision: 6},
+		},
+		WithPriceCacheTTL(ttl),
+		WithPriceCacheStaleWindow(staleWindow),
+	).(*tokensUseCase)
+
+	uc.RegisterPricingStrategy(testPricingSource, source)
+
+	return uc, source
+}
+
+// TestGetPrices_CacheMissThenHit verifies that a first call to GetPrices
+// reaches the pricing source (a cache miss), and an immediate second call is
+// served from the cache without calling the source again.
+func TestGetPrices_CacheMissThenHit(t *testing.T) {
+	uc, source := newTestTokensUseCase(50*time.Millisecond, 50*time.Millisecond)
+	ctx := context.Background()
+
+	if _, err := uc.GetPrices(ctx, []string{"uatom"}, []string{"uosmo"}, testPricingSource); err != nil {
+		t.Fatalf("first GetPrices() error = %v", err)
+	}
+	if got := source.calls.Load(); got != 1 {
+		t.Fatalf("calls after cache miss = %d, want 1", got)
+	}
+
+	if _, err := uc.GetPrices(ctx, []string{"uatom"}, []string{"uosmo"}, testPricingSource); err != nil {
+		t.Fatalf("second GetPrices() error = %v", err)
+	}
+	if got := source.calls.Load(); got != 1 {
+		t.Fatalf("calls after cache hit = %d, want still 1", got)
+	}
+}
+
+// TestGetPrices_StaleServesCachedValueAndTriggersAsyncRefresh verifies that,
+// once a cached entry's age is past the TTL but still within the stale
+// window, GetPrices returns immediately with the cached value and kicks off
+// an async refresh rather than blocking the caller on a synchronous fetch.
+func TestGetPrices_StaleServesCachedValueAndTriggersAsyncRefresh(t *testing.T) {
+	ttl := 20 * time.Millisecond
+	staleWindow := 200 * time.Millisecond
+	uc, source := newTestTokensUseCase(ttl, staleWindow)
+	ctx := context.Background()
+
+	if _, err := uc.GetPrices(ctx, []string{"uatom"}, []string{"uosmo"}, testPricingSource); err != nil {
+		t.Fatalf("priming GetPrices() error = %v", err)
+	}
+	if got := source.calls.Load(); got != 1 {
+		t.Fatalf("calls after priming = %d, want 1", got)
+	}
+
+	// Change what the source returns, so that a synchronous fetch can be
+	// told apart from the cached value being served.
+	source.price = osmomath.NewBigDec(3)
+
+	time.Sleep(ttl + 5*time.Millisecond)
+
+	prices, err := uc.GetPrices(ctx, []string{"uatom"}, []string{"uosmo"}, testPricingSource)
+	if err != nil {
+		t.Fatalf("stale-window GetPrices() error = %v", err)
+	}
+	if got, ok := prices["uatom"]["uosmo"].(osmomath.BigDec); !ok || !got.Equal(osmomath.NewBigDec(2)) {
+		t.Fatalf("stale-window price = %v, want the cached value 2", prices["uatom"]["uosmo"])
+	}
+
+	// Give the async refresh goroutine a chance to run, then confirm it did.
+	require := func(want int64) {
+		for deadline := time.Now().Add(staleWindow); time.Now().Before(deadline); {
+			if source.calls.Load() >= want {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		t.Fatalf("calls = %d, want at least %d (async refresh did not run)", source.calls.Load(), want)
+	}
+	require(2)
+}
+
+// TestGetPricesWithFreshness_DisallowStaleForcesSynchronousFetch verifies
+// that allowStale=false makes GetPricesWithFreshness treat any entry past
+// maxAge as a miss, forcing a synchronous re-fetch instead of serving a
+// stale value.
+func TestGetPricesWithFreshness_DisallowStaleForcesSynchronousFetch(t *testing.T) {
+	ttl := 10 * time.Millisecond
+	uc, source := newTestTokensUseCase(ttl, time.Hour)
+	ctx := context.Background()
+
+	if _, err := uc.GetPrices(ctx, []string{"uatom"}, []string{"uosmo"}, testPricingSource); err != nil {
+		t.Fatalf("priming GetPrices() error = %v", err)
+	}
+
+	time.Sleep(ttl + 5*time.Millisecond)
+
+	if _, err := uc.GetPricesWithFreshness(ctx, []string{"uatom"}, []string{"uosmo"}, testPricingSource, ttl, false); err != nil {
+		t.Fatalf("GetPricesWithFreshness() error = %v", err)
+	}
+
+	if got := source.calls.Load(); got != 2 {
+		t.Fatalf("calls with allowStale=false past maxAge = %d, want 2 (synchronous re-fetch)", got)
+	}
+}
+
+// blockingPricingSource counts concurrent in-flight GetPrice calls and the
+// max concurrency observed, blocking each call on release until told to
+// proceed, so tests can assert on how many calls pricingWorkerPool let
+// through at once.
+type blockingPricingSource struct {
+	concurrent atomic.Int64
+	maxSeen    atomic.Int64
+	release    chan struct{}
+}
+
+func (s *blockingPricingSource) GetPrice(ctx context.Context, baseDenom, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+	cur := s.concurrent.Add(1)
+	for {
+		if old := s.maxSeen.Load(); cur <= old || s.maxSeen.CompareAndSwap(old, cur) {
+			break
+		}
+	}
+
+	<-s.release
+
+	s.concurrent.Add(-1)
+	return osmomath.NewBigDec(1), nil
+}
+
+// TestGetPrices_WorkerPoolBoundsConcurrency verifies that pricingWorkerPool
+// actually bounds the number of concurrent GetPrice calls in flight, across
+// a batch with more quote denoms than the configured pool size.
+func TestGetPrices_WorkerPoolBoundsConcurrency(t *testing.T) {
+	const poolSize = 2
+
+	source := &blockingPricingSource{release: make(chan struct{})}
+
+	uc := NewTokensUsecase(
+		map[string]domain.Token{
+			"uatom": {HumanDenom: "atom", Precision: 6},
+			"uosmo": {HumanDenom: "osmo", Precision: 6},
+			"uusdc": {HumanDenom: "usdc", Precision: 6},
+			"uweth": {HumanDenom: "weth", Precision: 6},
+			"uavax": {HumanDenom: "avax", Precision: 6},
+		},
+		WithPricingWorkerPoolSize(poolSize),
+	).(*tokensUseCase)
+	uc.RegisterPricingStrategy(testPricingSource, source)
+
+	quoteDenoms := []string{"uosmo", "uusdc", "uweth", "uavax"}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := uc.GetPrices(context.Background(), []string{"uatom"}, quoteDenoms, testPricingSource); err != nil {
+			t.Errorf("GetPrices() error = %v", err)
+		}
+	}()
+
+	// Wait for the pool to saturate at its configured size.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && source.concurrent.Load() < poolSize {
+		time.Sleep(time.Millisecond)
+	}
+
+	if got := source.concurrent.Load(); got > poolSize {
+		t.Fatalf("concurrent in-flight calls = %d, want <= %d", got, poolSize)
+	}
+
+	close(source.release)
+	<-done
+
+	if got := source.maxSeen.Load(); got > poolSize {
+		t.Fatalf("max concurrent in-flight calls observed = %d, want <= %d", got, poolSize)
+	}
+}
+
+// TestReloadTokenMetadata_SwapsInNewTokens verifies that ReloadTokenMetadata
+// atomically replaces the token metadata snapshot, making a token that
+// wasn't present at construction time visible via GetMetadataByChainDenom
+// and GetFullTokenMetadata once the reload completes.
+func TestReloadTokenMetadata_SwapsInNewTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"chainName": "osmosis",
+			"assets": [
+				{"coinMinimalDenom": "uatom", "symbol": "ATOM", "decimals": 6, "coingeckoId": "cosmos"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	uc := NewTokensUsecase(
+		map[string]domain.Token{},
+		WithChainRegistryAssetsFileURL(server.URL),
+	).(*tokensUseCase)
+
+	if _, err := uc.GetMetadataByChainDenom("uatom"); err == nil {
+		t.Fatalf("GetMetadataByChainDenom(\"uatom\") before reload = nil error, want not found")
+	}
+
+	if err := uc.ReloadTokenMetadata(context.Background()); err != nil {
+		t.Fatalf("ReloadTokenMetadata() error = %v", err)
+	}
+
+	token, err := uc.GetMetadataByChainDenom("uatom")
+	if err != nil {
+		t.Fatalf("GetMetadataByChainDenom(\"uatom\") after reload error = %v", err)
+	}
+	if token.HumanDenom != "ATOM" || token.CoingeckoID != "cosmos" || token.Precision != 6 {
+		t.Fatalf("GetMetadataByChainDenom(\"uatom\") after reload = %+v, want HumanDenom=ATOM CoingeckoID=cosmos Precision=6", token)
+	}
+
+	full, err := uc.GetFullTokenMetadata()
+	if err != nil {
+		t.Fatalf("GetFullTokenMetadata() error = %v", err)
+	}
+	if _, ok := full["uatom"]; !ok {
+		t.Fatalf("GetFullTokenMetadata() = %+v, want it to contain uatom after reload", full)
+	}
+}
+
+// failingPricingSource always fails, recording how many times it was called.
+type failingPricingSource struct {
+	calls atomic.Int64
+}
+
+func (s *failingPricingSource) GetPrice(ctx context.Context, baseDenom, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+	s.calls.Add(1)
+	return osmomath.BigDec{}, fmt.Errorf("source unavailable")
+}
+
+// TestGetPriceWithFallback_CycleGuardTerminates verifies that a misconfigured
+// fallback cycle (A falls back to B, B falls back to A) still terminates,
+// calling each source's strategy exactly once and firing fallbackCounter
+// exactly once for the single hop taken, rather than looping forever.
+func TestGetPriceWithFallback_CycleGuardTerminates(t *testing.T) {
+	sourceA := domain.PricingSourceType(20)
+	sourceB := domain.PricingSourceType(21)
+
+	stratA := &failingPricingSource{}
+	stratB := &failingPricingSource{}
+
+	uc := NewTokensUsecase(map[string]domain.Token{
+		"uatom": {HumanDenom: "atom", Precision: 6},
+		"uosmo": {HumanDenom: "osmo", Precision: 6},
+	}).(*tokensUseCase)
+
+	uc.RegisterPricingStrategy(sourceA, stratA)
+	uc.RegisterPricingStrategy(sourceB, stratB)
+	if err := uc.SetPricingFallbacks(sourceA, sourceB); err != nil {
+		t.Fatalf("SetPricingFallbacks(sourceA) error = %v", err)
+	}
+	if err := uc.SetPricingFallbacks(sourceB, sourceA); err != nil {
+		t.Fatalf("SetPricingFallbacks(sourceB) error = %v", err)
+	}
+
+	hopLabels := []string{fmt.Sprintf("%v", sourceA), fmt.Sprintf("%v", sourceB), "uatom", "uosmo"}
+	before := testutil.ToFloat64(fallbackCounter.WithLabelValues(hopLabels...))
+
+	if _, err := uc.getPriceWithFallback(context.Background(), "uatom", "uosmo", sourceA); err == nil {
+		t.Fatalf("getPriceWithFallback() error = nil, want an error from an all-failing cycle")
+	}
+
+	if got := stratA.calls.Load(); got != 1 {
+		t.Fatalf("sourceA strategy calls = %d, want 1", got)
+	}
+	if got := stratB.calls.Load(); got != 1 {
+		t.Fatalf("sourceB strategy calls = %d, want 1", got)
+	}
+
+	after := testutil.ToFloat64(fallbackCounter.WithLabelValues(hopLabels...))
+	if after != before+1 {
+		t.Fatalf("fallbackCounter{from=A,to=B} = %v, want %v", after, before+1)
+	}
+}